@@ -9,13 +9,13 @@ func ExampleIniParser_LoadFromString() {
 	ini := New()
 	ini.LoadFromString(iniContent)
 	fmt.Println(ini.sections)
-	// Output: map[database:map[file:"payroll.dat" port:143 server:192.0.2.62] owner:map[name:John Doe organization:Acme Inc.]]
+	// Output: map[database:map[file:payroll.dat port:143 server:192.0.2.62] owner:map[name:John Doe organization:Acme Inc.]]
 }
 func ExampleIniParser_LoadFromFile() {
 	ini := New()
 	ini.LoadFromFile(exampleFilePath)
 	fmt.Println(ini.sections)
-	// output: map[database:map[file:"payroll.dat" port:143 server:192.0.2.62] owner:map[name:John Doe organization:Acme Inc.]]
+	// output: map[database:map[file:payroll.dat port:143 server:192.0.2.62] owner:map[name:John Doe organization:Acme Inc.]]
 }
 
 func ExampleIniParser_GetSections() {
@@ -23,7 +23,7 @@ func ExampleIniParser_GetSections() {
 	ini.LoadFromFile(exampleFilePath)
 	sections := ini.GetSections()
 	fmt.Println(sections)
-	// Output: map[database:map[file:"payroll.dat" port:143 server:192.0.2.62] owner:map[name:John Doe organization:Acme Inc.]]
+	// Output: map[database:map[file:payroll.dat port:143 server:192.0.2.62] owner:map[name:John Doe organization:Acme Inc.]]
 }
 
 func ExampleIniParser_GetSectionNames() {
@@ -31,7 +31,7 @@ func ExampleIniParser_GetSectionNames() {
 	ini.LoadFromFile(exampleFilePath)
 	sections := ini.GetSectionNames()
 	fmt.Println(sections)
-	// Output: [database owner]
+	// Output: [owner database]
 }
 
 func ExampleIniParser_Get() {
@@ -46,7 +46,8 @@ func ExampleIniParser_Set() {
 	ini := New()
 	ini.LoadFromFile(exampleFilePath)
 	ini.Set("owner", "name", "salah")
-	fmt.Println(ini.sections["owner"]["name"])
+	name, _ := ini.sections["owner"].Get("name")
+	fmt.Println(name)
 	// Output: salah
 }
 
@@ -0,0 +1,81 @@
+package iniparser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+// bomKind identifies the byte-order-mark (if any) a document was read with,
+// so WriteTo can re-encode it the same way on the way back out.
+type bomKind int
+
+const (
+	bomNone bomKind = iota
+	bomUTF8
+	bomUTF16LE
+	bomUTF16BE
+)
+
+// stripBOM peeks at the first bytes of r, consumes a UTF-8 or UTF-16 BOM if
+// present, and returns a reader positioned after it together with the kind
+// of BOM found. UTF-16 content is decoded to UTF-8 so downstream parsing
+// only ever has to deal with plain text.
+func stripBOM(r io.Reader) (io.Reader, bomKind) {
+	br := bufio.NewReader(r)
+
+	prefix, _ := br.Peek(3)
+	if bytes.HasPrefix(prefix, []byte{0xEF, 0xBB, 0xBF}) {
+		br.Discard(3)
+		return br, bomUTF8
+	}
+	if bytes.HasPrefix(prefix, []byte{0xFF, 0xFE}) {
+		br.Discard(2)
+		return decodeUTF16(br, binary.LittleEndian), bomUTF16LE
+	}
+	if bytes.HasPrefix(prefix, []byte{0xFE, 0xFF}) {
+		br.Discard(2)
+		return decodeUTF16(br, binary.BigEndian), bomUTF16BE
+	}
+
+	return br, bomNone
+}
+
+// decodeUTF16 reads the remainder of r as UTF-16 code units in the given
+// byte order and returns a reader over the decoded UTF-8 text.
+func decodeUTF16(r io.Reader, order binary.ByteOrder) io.Reader {
+	raw, _ := io.ReadAll(r)
+	units := make([]uint16, len(raw)/2)
+	for i := range units {
+		units[i] = order.Uint16(raw[i*2 : i*2+2])
+	}
+	return strings.NewReader(string(utf16.Decode(units)))
+}
+
+// encodeWithBOM prepends the BOM bytes for kind to content (re-encoding it
+// as UTF-16 first if necessary). bomNone returns content unchanged.
+func encodeWithBOM(content string, kind bomKind) []byte {
+	switch kind {
+	case bomUTF8:
+		return append([]byte{0xEF, 0xBB, 0xBF}, content...)
+	case bomUTF16LE:
+		return encodeUTF16(content, binary.LittleEndian, []byte{0xFF, 0xFE})
+	case bomUTF16BE:
+		return encodeUTF16(content, binary.BigEndian, []byte{0xFE, 0xFF})
+	default:
+		return []byte(content)
+	}
+}
+
+func encodeUTF16(content string, order binary.ByteOrder, bom []byte) []byte {
+	units := utf16.Encode([]rune(content))
+	out := make([]byte, len(bom)+len(units)*2)
+	copy(out, bom)
+	for i, u := range units {
+		order.PutUint16(out[len(bom)+i*2:], u)
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package iniparser
+
+// HasSection reports whether sectionName exists.
+func (i *IniParser) HasSection(sectionName SectionName) bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	_, ok := i.sections[sectionName]
+	return ok
+}
+
+// HasKey reports whether key exists within sectionName.
+func (i *IniParser) HasKey(sectionName SectionName, key Key) bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	section, ok := i.sections[sectionName]
+	if !ok {
+		return false
+	}
+	return section.HasKey(key)
+}
+
+// AddSection creates an empty section named sectionName if it doesn't
+// already exist, appending it to the end of the section order. It returns
+// ErrEmptySectionName if sectionName is empty.
+func (i *IniParser) AddSection(sectionName SectionName) error {
+	if sectionName == "" {
+		return ErrEmptySectionName
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.sections == nil {
+		i.sections = map[SectionName]Section{}
+	}
+	if _, ok := i.sections[sectionName]; ok {
+		return nil
+	}
+	i.sections[sectionName] = Section{}
+	i.sectionOrder = append(i.sectionOrder, sectionName)
+	return nil
+}
+
+// DeleteSection removes sectionName and all of its keys. It returns
+// ErrSectionNotExist if no such section exists.
+func (i *IniParser) DeleteSection(sectionName SectionName) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if _, ok := i.sections[sectionName]; !ok {
+		return ErrSectionNotExist
+	}
+	delete(i.sections, sectionName)
+	for idx, name := range i.sectionOrder {
+		if name == sectionName {
+			i.sectionOrder = append(i.sectionOrder[:idx], i.sectionOrder[idx+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// DeleteKey removes key from sectionName. It returns ErrSectionNotExist if
+// the section doesn't exist, or ErrKeyNotExist if the key doesn't.
+func (i *IniParser) DeleteKey(sectionName SectionName, key Key) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	section, ok := i.sections[sectionName]
+	if !ok {
+		return ErrSectionNotExist
+	}
+	if !section.Delete(key) {
+		return ErrKeyNotExist
+	}
+	i.sections[sectionName] = section
+	return nil
+}
+
+// Snapshot returns a deep copy of i, suitable for a reader that wants a
+// stable view of the data while another goroutine continues to mutate the
+// original.
+func (i *IniParser) Snapshot() *IniParser {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return &IniParser{
+		sections:              cloneSections(i.sections),
+		sectionOrder:          append([]SectionName(nil), i.sectionOrder...),
+		preamble:              append([]string(nil), i.preamble...),
+		listSeparator:         i.listSeparator,
+		inlineCommentsEnabled: i.inlineCommentsEnabled,
+		interpolationEnabled:  i.interpolationEnabled,
+		defaultSection:        i.defaultSection,
+		customResolver:        i.customResolver,
+		bom:                   i.bom,
+	}
+}
+
+// cloneSections returns a deep copy of sections, so that mutating the copy
+// cannot race with mutations to the original map or its Sections.
+func cloneSections(sections map[SectionName]Section) map[SectionName]Section {
+	clone := make(map[SectionName]Section, len(sections))
+	for name, section := range sections {
+		clone[name] = section.clone()
+	}
+	return clone
+}
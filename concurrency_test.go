@@ -0,0 +1,180 @@
+package iniparser
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentGetSet(t *testing.T) {
+	ini := New()
+	err := ini.LoadFromString("[counters]\na = 0\nb = 0")
+	assertNoErrorMsg(t, err)
+
+	var wg sync.WaitGroup
+	for n := 0; n < 50; n++ {
+		wg.Add(2)
+		go func(n int) {
+			defer wg.Done()
+			ini.Set("counters", "a", string(rune('0'+n%10)))
+		}(n)
+		go func() {
+			defer wg.Done()
+			if _, err := ini.Get("counters", "b"); err != nil {
+				t.Errorf("Get failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrentReadsDuringSectionMutation(t *testing.T) {
+	ini := New()
+	err := ini.LoadFromString("[owner]\nname = John Doe")
+	assertNoErrorMsg(t, err)
+
+	var wg sync.WaitGroup
+	for n := 0; n < 50; n++ {
+		wg.Add(3)
+		go func(n int) {
+			defer wg.Done()
+			ini.AddSection(SectionName("section-" + string(rune('a'+n%26))))
+		}(n)
+		go func() {
+			defer wg.Done()
+			ini.GetSections()
+			ini.GetSectionNames()
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := ini.String(); err != nil {
+				t.Errorf("String failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSnapshotIsolatedFromFurtherMutation(t *testing.T) {
+	ini := New()
+	err := ini.LoadFromString("[owner]\nname = John Doe")
+	assertNoErrorMsg(t, err)
+
+	snap := ini.Snapshot()
+
+	err = ini.Set("owner", "name", "Ahmed Salah")
+	assertNoErrorMsg(t, err)
+
+	got, err := snap.Get("owner", "name")
+	assertNoErrorMsg(t, err)
+	assertEqualStrings(t, got, "John Doe")
+}
+
+func TestConcurrentGetChildSectionsAndAddSection(t *testing.T) {
+	ini := New()
+	err := ini.LoadFromString("[parent]\nname = John Doe\n\n[parent.child]\nname = Jane Doe")
+	assertNoErrorMsg(t, err)
+
+	var wg sync.WaitGroup
+	for n := 0; n < 50; n++ {
+		wg.Add(2)
+		go func(n int) {
+			defer wg.Done()
+			ini.AddSection(SectionName("parent." + string(rune('a'+n%26))))
+		}(n)
+		go func() {
+			defer wg.Done()
+			ini.GetChildSections("parent")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrentMapToAndSet(t *testing.T) {
+	ini := New()
+	err := ini.LoadFromString(iniContent)
+	assertNoErrorMsg(t, err)
+
+	var wg sync.WaitGroup
+	for n := 0; n < 50; n++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			var cfg testConfig
+			if err := ini.MapTo(&cfg); err != nil {
+				t.Errorf("MapTo failed: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			ini.Set("owner", "name", "Ahmed Salah")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrentLoadFrom(t *testing.T) {
+	var wg sync.WaitGroup
+	ini := New()
+	for n := 0; n < 50; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ini.LoadFrom(strings.NewReader(iniContent))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrentLoadFromStringAndGet(t *testing.T) {
+	ini := New()
+	err := ini.LoadFromString(iniContent)
+	assertNoErrorMsg(t, err)
+
+	var wg sync.WaitGroup
+	for n := 0; n < 50; n++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			ini.LoadFromString(iniContent)
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := ini.Get("owner", "name"); err != nil {
+				t.Errorf("Get failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestAddDeleteSectionAndKey(t *testing.T) {
+	ini := New()
+	err := ini.LoadFromString("[owner]\nname = John Doe")
+	assertNoErrorMsg(t, err)
+
+	if ini.HasSection("database") {
+		t.Fatal("expected section \"database\" not to exist yet")
+	}
+	assertNoErrorMsg(t, ini.AddSection("database"))
+	if !ini.HasSection("database") {
+		t.Fatal("expected section \"database\" to exist after AddSection")
+	}
+
+	if ini.HasKey("owner", "organization") {
+		t.Fatal("expected key \"organization\" not to exist yet")
+	}
+	assertNoErrorMsg(t, ini.Set("owner", "name", "Ahmed Salah"))
+
+	assertErrorMsg(t, ini.DeleteKey("owner", "missing"), ErrKeyNotExist)
+	assertNoErrorMsg(t, ini.DeleteKey("owner", "name"))
+	if ini.HasKey("owner", "name") {
+		t.Fatal("expected key \"name\" to be gone after DeleteKey")
+	}
+
+	assertErrorMsg(t, ini.DeleteSection("missing"), ErrSectionNotExist)
+	assertNoErrorMsg(t, ini.DeleteSection("database"))
+	if ini.HasSection("database") {
+		t.Fatal("expected section \"database\" to be gone after DeleteSection")
+	}
+}
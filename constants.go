@@ -70,11 +70,31 @@ port = 143
 
 file = "payroll.dat"`
 
-var mapOfSections = map[SectionName]Section{
-	SectionName("owner") : {Key("name") : "John Doe",
-							Key("organization") : "Acme Inc."},
+// kv is an ordered key/value pair, used to build Section fixtures whose
+// Pairs() must come out in a specific order.
+type kv struct {
+	key   string
+	value string
+}
+
+// buildSection returns a Section populated with pairs, in order.
+func buildSection(pairs ...kv) Section {
+	var section Section
+	for _, pair := range pairs {
+		section.Set(Key(pair.key), pair.value)
+	}
+	return section
+}
 
-	SectionName("database") : {Key("server") : "192.0.2.62",
-							   Key("port") : "143",
-							   Key("file") : "\"payroll.dat\"",},
+var mapOfSections = map[SectionName]Section{
+	SectionName("owner"): buildSection(
+		kv{"name", "John Doe"},
+		kv{"organization", "Acme Inc."},
+	),
+
+	SectionName("database"): buildSection(
+		kv{"server", "192.0.2.62"},
+		kv{"port", "143"},
+		kv{"file", "payroll.dat"},
+	),
 }
\ No newline at end of file
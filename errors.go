@@ -1,5 +1,7 @@
 package iniparser
 
+import "strings"
+
 var (
 	ErrInvalidFilePath = IniParserError("couldn't find the file in the path you provided")
 	ErrNullReference = IniParserError("you tried to access object that doesn't exist")
@@ -10,8 +12,28 @@ var (
 	ErrEmptySectionName = IniParserError("you should provide sectionName")
 	ErrEmptyKey = IniParserError("you should provide key for the properity")
 	ErrSyntaxError = IniParserError("syntax error, can't understand this line")
+	ErrNotAPointer = IniParserError("v must be a non-nil pointer")
+	ErrNotAStruct = IniParserError("v must be a pointer to a struct")
+	ErrRequiredFieldMissing = IniParserError("required field is missing from the ini data")
+	ErrUnsupportedFieldType = IniParserError("field type is not supported for conversion")
+	ErrInterpolationCycle = IniParserError("interpolation cycle detected")
 )
 
+// InterpolationCycleError reports the chain of section.key references that
+// led back to a reference already being resolved.
+type InterpolationCycleError struct {
+	Chain []string
+	Err   error
+}
+
+func (e *InterpolationCycleError) Error() string {
+	return e.Err.Error() + ": " + strings.Join(e.Chain, " -> ")
+}
+
+func (e *InterpolationCycleError) Unwrap() error {
+	return e.Err
+}
+
 type IniParserError string
 
 func (e IniParserError) Error() string {
@@ -0,0 +1,143 @@
+package iniparser
+
+import "testing"
+
+func TestHashComments(t *testing.T) {
+	ini := New()
+	err := ini.LoadFromString("# top comment\n[owner]\nname = John Doe\n# another comment\norganization = Acme Inc.")
+
+	assertNoErrorMsg(t, err)
+	got, err := ini.Get("owner", "organization")
+	assertNoErrorMsg(t, err)
+	assertEqualStrings(t, got, "Acme Inc.")
+}
+
+func TestQuotedValues(t *testing.T) {
+	t.Run("double quotes preserve embedded separators", func(t *testing.T) {
+		ini := New()
+		err := ini.LoadFromString(`[database]
+dsn = "user=admin;password=secret"`)
+
+		assertNoErrorMsg(t, err)
+		got, err := ini.Get("database", "dsn")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "user=admin;password=secret")
+	})
+
+	t.Run("single quotes preserve leading and trailing whitespace", func(t *testing.T) {
+		ini := New()
+		err := ini.LoadFromString("[owner]\nname = '  padded  '")
+
+		assertNoErrorMsg(t, err)
+		got, err := ini.Get("owner", "name")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "  padded  ")
+	})
+}
+
+func TestInlineComments(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		ini := New()
+		err := ini.LoadFromString("[owner]\nname = John ; who owns this")
+
+		assertNoErrorMsg(t, err)
+		got, err := ini.Get("owner", "name")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "John ; who owns this")
+	})
+
+	t.Run("stripped when enabled", func(t *testing.T) {
+		ini := New()
+		ini.SetInlineCommentsEnabled(true)
+		err := ini.LoadFromString("[owner]\nname = John ; who owns this")
+
+		assertNoErrorMsg(t, err)
+		got, err := ini.Get("owner", "name")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "John")
+	})
+
+	t.Run("quoted value keeps comment chars even when enabled", func(t *testing.T) {
+		ini := New()
+		ini.SetInlineCommentsEnabled(true)
+		err := ini.LoadFromString(`[owner]
+name = "John ; Doe"`)
+
+		assertNoErrorMsg(t, err)
+		got, err := ini.Get("owner", "name")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "John ; Doe")
+	})
+
+	t.Run("quoted value followed by a trailing comment is unquoted", func(t *testing.T) {
+		ini := New()
+		ini.SetInlineCommentsEnabled(true)
+		err := ini.LoadFromString(`[owner]
+name = "John Doe" ; who owns this`)
+
+		assertNoErrorMsg(t, err)
+		got, err := ini.Get("owner", "name")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "John Doe")
+	})
+}
+
+func TestLineContinuations(t *testing.T) {
+	ini := New()
+	err := ini.LoadFromString("[owner]\nname = John \\\nDoe")
+
+	assertNoErrorMsg(t, err)
+	got, err := ini.Get("owner", "name")
+	assertNoErrorMsg(t, err)
+	assertEqualStrings(t, got, "John \nDoe")
+}
+
+func TestLineContinuationRoundTripsThroughString(t *testing.T) {
+	ini := New()
+	err := ini.LoadFromString("[owner]\nname = John \\\nDoe")
+	assertNoErrorMsg(t, err)
+
+	out, err := ini.String()
+	assertNoErrorMsg(t, err)
+
+	reloaded := New()
+	err = reloaded.LoadFromString(out)
+	assertNoErrorMsg(t, err)
+
+	got, err := reloaded.Get("owner", "name")
+	assertNoErrorMsg(t, err)
+	assertEqualStrings(t, got, "John \nDoe")
+}
+
+func TestDottedSections(t *testing.T) {
+	ini := New()
+	err := ini.LoadFromString("[server]\nhost = localhost\n\n[server.prod]\nhost = prod.example.com\n\n[server.prod.eu]\nhost = eu.example.com")
+
+	assertNoErrorMsg(t, err)
+	got := ini.GetChildSections("server")
+	want := []string{"server.prod"}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("got %v want %v", got, want)
+	}
+
+	value, err := ini.Get("server.prod.eu", "host")
+	assertNoErrorMsg(t, err)
+	assertEqualStrings(t, value, "eu.example.com")
+}
+
+func TestStringQuotesValuesThatNeedIt(t *testing.T) {
+	ini := New()
+	ini.LoadFromString(`[database]
+dsn = "user=admin;password=secret"`)
+
+	out, err := ini.String()
+	assertNoErrorMsg(t, err)
+
+	roundTripped := New()
+	err = roundTripped.LoadFromString(out)
+	assertNoErrorMsg(t, err)
+
+	got, err := roundTripped.Get("database", "dsn")
+	assertNoErrorMsg(t, err)
+	assertEqualStrings(t, got, "user=admin;password=secret")
+}
@@ -0,0 +1,188 @@
+package iniparser
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	percentRefPattern = regexp.MustCompile(`%\(([^)]+)\)s`)
+	braceRefPattern   = regexp.MustCompile(`\$\{([^}]+)\}`)
+)
+
+// SetInterpolationEnabled controls whether Get expands variable references
+// in a value before returning it. It is disabled by default so existing
+// callers keep seeing raw values. GetRaw and GetExpanded ignore this flag.
+func (i *IniParser) SetInterpolationEnabled(enabled bool) {
+	i.interpolationEnabled = enabled
+}
+
+// SetDefaultSection names the section consulted for a "%(key)s" reference
+// that isn't found in the section being expanded, mirroring the
+// "[DEFAULT]" fallback section of configparser-style INI dialects.
+func (i *IniParser) SetDefaultSection(name SectionName) {
+	i.defaultSection = name
+}
+
+// SetInterpolationResolver installs a hook consulted before the built-in
+// resolution rules for every reference found during expansion, both the
+// "%(key)s" form (called with "key") and the "${...}" form (called with
+// its full contents, e.g. "section:key" or "env:HOME"). Returning ok == false
+// falls through to the built-in rules.
+func (i *IniParser) SetInterpolationResolver(resolver func(ref string) (string, bool)) {
+	i.customResolver = resolver
+}
+
+// GetRaw returns the value stored under key without performing variable
+// interpolation, regardless of SetInterpolationEnabled.
+func (i *IniParser) GetRaw(sectionName SectionName, key Key) (string, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.getRaw(sectionName, key)
+}
+
+// GetExpanded returns the value stored under key with variable
+// interpolation applied, regardless of SetInterpolationEnabled.
+//
+// A value may reference other keys via "%(key)s" (resolved in the same
+// section, falling back to the section set with SetDefaultSection),
+// "${section:key}" (resolved in the named section), or "${env:NAME}"
+// (resolved from the environment). References are expanded recursively; a
+// reference that forms a cycle fails with an *InterpolationCycleError
+// wrapping ErrInterpolationCycle.
+func (i *IniParser) GetExpanded(sectionName SectionName, key Key) (string, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	raw, err := i.getRaw(sectionName, key)
+	if err != nil {
+		return "", err
+	}
+	return i.expandValue(raw, sectionName, key)
+}
+
+func (i *IniParser) getRaw(sectionName SectionName, key Key) (string, error) {
+	if i.sections == nil {
+		return "", ErrNullReference
+	}
+	section, ok := i.sections[sectionName]
+	if !ok {
+		return "", ErrSectionNotExist
+	}
+	value, ok := section.Get(key)
+	if !ok {
+		return "", ErrKeyNotExist
+	}
+	return value, nil
+}
+
+func (i *IniParser) expandValue(raw string, sectionName SectionName, key Key) (string, error) {
+	visited := map[string]int{visitKey(sectionName, key): 0}
+	return i.expand(raw, sectionName, visited)
+}
+
+func visitKey(sectionName SectionName, key Key) string {
+	return string(sectionName) + "." + string(key)
+}
+
+// expand replaces every "%(key)s" and "${...}" reference in value, in that
+// order, resolving references against sectionName.
+func (i *IniParser) expand(value string, sectionName SectionName, visited map[string]int) (string, error) {
+	var expandErr error
+
+	replace := func(pattern *regexp.Regexp, resolve func(ref string) (string, error)) string {
+		return pattern.ReplaceAllStringFunc(value, func(match string) string {
+			if expandErr != nil {
+				return match
+			}
+			ref := pattern.FindStringSubmatch(match)[1]
+			resolved, err := resolve(ref)
+			if err != nil {
+				expandErr = err
+				return match
+			}
+			return resolved
+		})
+	}
+
+	value = replace(percentRefPattern, func(ref string) (string, error) {
+		return i.resolvePercentRef(sectionName, Key(ref), visited)
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+
+	value = replace(braceRefPattern, func(ref string) (string, error) {
+		return i.resolveBraceRef(sectionName, ref, visited)
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+
+	return value, nil
+}
+
+func (i *IniParser) resolvePercentRef(currentSection SectionName, key Key, visited map[string]int) (string, error) {
+	if i.customResolver != nil {
+		if val, ok := i.customResolver(string(key)); ok {
+			return i.expandResolved(val, currentSection, visitKey(currentSection, key), visited)
+		}
+	}
+
+	section := currentSection
+	raw, ok := i.lookupKey(section, key)
+	if !ok && i.defaultSection != "" {
+		section = i.defaultSection
+		raw, ok = i.lookupKey(section, key)
+	}
+	if !ok {
+		return "", ErrKeyNotExist
+	}
+	return i.expandResolved(raw, section, visitKey(section, key), visited)
+}
+
+func (i *IniParser) resolveBraceRef(currentSection SectionName, ref string, visited map[string]int) (string, error) {
+	if i.customResolver != nil {
+		if val, ok := i.customResolver(ref); ok {
+			return i.expandResolved(val, currentSection, "${"+ref+"}", visited)
+		}
+	}
+
+	scope, name, ok := strings.Cut(ref, ":")
+	if !ok {
+		return "", ErrSyntaxError
+	}
+	if scope == "env" {
+		return os.Getenv(name), nil
+	}
+
+	section := SectionName(scope)
+	key := Key(name)
+	raw, ok := i.lookupKey(section, key)
+	if !ok {
+		return "", ErrKeyNotExist
+	}
+	return i.expandResolved(raw, section, visitKey(section, key), visited)
+}
+
+// expandResolved tracks visitedKey only for the duration of expanding raw,
+// removing it again once that chain unwinds. Without this, two independent
+// (non-cyclic) references to the same key within one value, e.g.
+// "%(base)s/%(base)s", would trip the cycle check on the second occurrence
+// just because the first left its key marked in the shared visited map.
+// Each key maps to its depth in the chain, so a cycle error lists the keys
+// in the order they were followed.
+func (i *IniParser) expandResolved(raw string, section SectionName, visitedKey string, visited map[string]int) (string, error) {
+	if _, ok := visited[visitedKey]; ok {
+		chain := make([]string, len(visited), len(visited)+1)
+		for k, depth := range visited {
+			chain[depth] = k
+		}
+		chain = append(chain, visitedKey)
+		return "", &InterpolationCycleError{Chain: chain, Err: ErrInterpolationCycle}
+	}
+	visited[visitedKey] = len(visited)
+	defer delete(visited, visitedKey)
+	return i.expand(raw, section, visited)
+}
@@ -0,0 +1,107 @@
+package iniparser
+
+import (
+	"os"
+	"testing"
+)
+
+func TestGetInterpolation(t *testing.T) {
+	t.Run("disabled by default returns raw value", func(t *testing.T) {
+		ini := New()
+		ini.LoadFromString("[paths]\nbase = /srv\nlogs = %(base)s/logs")
+
+		got, err := ini.Get("paths", "logs")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "%(base)s/logs")
+	})
+
+	t.Run("expands same-section reference when enabled", func(t *testing.T) {
+		ini := New()
+		ini.SetInterpolationEnabled(true)
+		ini.LoadFromString("[paths]\nbase = /srv\nlogs = %(base)s/logs")
+
+		got, err := ini.Get("paths", "logs")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "/srv/logs")
+	})
+
+	t.Run("falls back to default section", func(t *testing.T) {
+		ini := New()
+		ini.SetDefaultSection("DEFAULT")
+		ini.LoadFromString("[DEFAULT]\nbase = /srv\n\n[paths]\nlogs = %(base)s/logs")
+
+		got, err := ini.GetExpanded("paths", "logs")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "/srv/logs")
+	})
+
+	t.Run("explicit section:key reference", func(t *testing.T) {
+		ini := New()
+		ini.LoadFromString("[paths]\nbase = /srv\n\n[logging]\nfile = ${paths:base}/app.log")
+
+		got, err := ini.GetExpanded("logging", "file")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "/srv/app.log")
+	})
+
+	t.Run("environment reference", func(t *testing.T) {
+		os.Setenv("INI_PARSER_TEST_VAR", "hello")
+		defer os.Unsetenv("INI_PARSER_TEST_VAR")
+
+		ini := New()
+		ini.LoadFromString("[greeting]\nmsg = ${env:INI_PARSER_TEST_VAR}, world")
+
+		got, err := ini.GetExpanded("greeting", "msg")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "hello, world")
+	})
+
+	t.Run("cycle detection", func(t *testing.T) {
+		ini := New()
+		ini.LoadFromString("[a]\nx = %(y)s\ny = %(x)s")
+
+		_, err := ini.GetExpanded("a", "x")
+		if err == nil {
+			t.Fatal("expected a cycle error, got nil")
+		}
+		cycleErr, ok := err.(*InterpolationCycleError)
+		if !ok {
+			t.Fatalf("got error of type %T, want *InterpolationCycleError", err)
+		}
+		assertErrorMsg(t, cycleErr.Unwrap(), ErrInterpolationCycle)
+	})
+
+	t.Run("repeated non-cyclic reference in one value", func(t *testing.T) {
+		ini := New()
+		ini.LoadFromString("[paths]\nbase = srv\nlogs = %(base)s-%(base)s-logs")
+
+		got, err := ini.GetExpanded("paths", "logs")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "srv-srv-logs")
+	})
+
+	t.Run("custom resolver hook", func(t *testing.T) {
+		ini := New()
+		ini.SetInterpolationResolver(func(ref string) (string, bool) {
+			if ref == "version" {
+				return "1.2.3", true
+			}
+			return "", false
+		})
+		ini.LoadFromString("[app]\nbanner = release %(version)s")
+
+		got, err := ini.GetExpanded("app", "banner")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "release 1.2.3")
+	})
+
+	t.Run("GetRaw ignores interpolation setting", func(t *testing.T) {
+		ini := New()
+		ini.SetInterpolationEnabled(true)
+		ini.LoadFromString("[paths]\nbase = /srv\nlogs = %(base)s/logs")
+
+		got, err := ini.GetRaw("paths", "logs")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "%(base)s/logs")
+	})
+}
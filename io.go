@@ -0,0 +1,101 @@
+package iniparser
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+// LoadFrom reads ini data from r and loads it into the object's sections
+// field, replacing any data previously loaded. A leading UTF-8 or UTF-16
+// (LE/BE) byte-order-mark is detected, stripped, and remembered so WriteTo
+// can reproduce it. Errors are the same as LoadFromString.
+func (i *IniParser) LoadFrom(r io.Reader) (err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	decoded, bom := stripBOM(r)
+	i.sections, i.sectionOrder, i.preamble, err = parseReader(decoded, i.inlineCommentsEnabled)
+	i.bom = bom
+	return err
+}
+
+// WriteTo writes the object's current contents to w, re-encoded with
+// whatever byte-order-mark was detected by the most recent LoadFrom (if
+// any), and returns the number of bytes written. String() and the read of
+// i.bom each take i.mu separately, so a load that runs in between can pair
+// the rendered content with the newer BOM.
+func (i *IniParser) WriteTo(w io.Writer) (int64, error) {
+	content, err := i.String()
+	if err != nil {
+		return 0, err
+	}
+
+	i.mu.RLock()
+	bom := i.bom
+	i.mu.RUnlock()
+
+	n, err := w.Write(encodeWithBOM(content, bom))
+	return int64(n), err
+}
+
+// LoadSources loads and merges one or more ini sources in order, with keys
+// from later sources overriding keys from earlier ones in place; keys that
+// don't already exist are appended to their section. Sections are created
+// in the order they are first seen across all sources. Each source must be
+// a file path (string), raw ini data ([]byte), or an io.Reader; any other
+// type returns ErrUnsupportedFieldType.
+func (i *IniParser) LoadSources(sources ...interface{}) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.sections == nil {
+		i.sections = map[SectionName]Section{}
+	}
+
+	for _, source := range sources {
+		var r io.Reader
+		switch src := source.(type) {
+		case string:
+			fileContent, err := os.ReadFile(src)
+			if err != nil {
+				return ErrInvalidFilePath
+			}
+			r = bytes.NewReader(fileContent)
+		case []byte:
+			r = bytes.NewReader(src)
+		case io.Reader:
+			r = src
+		default:
+			return ErrUnsupportedFieldType
+		}
+
+		decoded, _ := stripBOM(r)
+		sections, order, preamble, err := parseReader(decoded, i.inlineCommentsEnabled)
+		if err != nil {
+			return err
+		}
+		i.mergeSources(sections, order, preamble)
+	}
+
+	return nil
+}
+
+// mergeSources folds a freshly-parsed document into i, appending new
+// sections/keys and overriding existing keys in place.
+func (i *IniParser) mergeSources(sections map[SectionName]Section, order []SectionName, preamble []string) {
+	if i.preamble == nil {
+		i.preamble = preamble
+	}
+
+	for _, name := range order {
+		existing, ok := i.sections[name]
+		if !ok {
+			i.sectionOrder = append(i.sectionOrder, name)
+		}
+		for _, pair := range sections[name].Pairs() {
+			existing.Set(pair.Key, pair.Value)
+		}
+		i.sections[name] = existing
+	}
+}
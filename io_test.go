@@ -0,0 +1,106 @@
+package iniparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func TestLoadFrom(t *testing.T) {
+	t.Run("loads from a plain reader", func(t *testing.T) {
+		ini := New()
+		err := ini.LoadFrom(strings.NewReader(iniContent))
+		assertNoErrorMsg(t, err)
+
+		got, err := ini.Get("owner", "name")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "John Doe")
+	})
+
+	t.Run("strips a UTF-8 BOM", func(t *testing.T) {
+		ini := New()
+		data := append([]byte{0xEF, 0xBB, 0xBF}, []byte("[owner]\nname = John Doe")...)
+		err := ini.LoadFrom(bytes.NewReader(data))
+		assertNoErrorMsg(t, err)
+
+		got, err := ini.Get("owner", "name")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "John Doe")
+	})
+
+	t.Run("strips and round-trips a UTF-16LE BOM", func(t *testing.T) {
+		ini := New()
+		data := encodeUTF16("[owner]\nname = John Doe", binary.LittleEndian, []byte{0xFF, 0xFE})
+		err := ini.LoadFrom(bytes.NewReader(data))
+		assertNoErrorMsg(t, err)
+
+		got, err := ini.Get("owner", "name")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "John Doe")
+
+		var out bytes.Buffer
+		if _, err := ini.WriteTo(&out); err != nil {
+			t.Fatalf("WriteTo failed: %v", err)
+		}
+		if !bytes.HasPrefix(out.Bytes(), []byte{0xFF, 0xFE}) {
+			t.Fatalf("expected output to start with a UTF-16LE BOM, got %v", out.Bytes()[:2])
+		}
+	})
+}
+
+func TestWriteTo(t *testing.T) {
+	t.Run("round-trips plain content with no BOM", func(t *testing.T) {
+		ini := New()
+		err := ini.LoadFromString("[owner]\nname = John Doe")
+		assertNoErrorMsg(t, err)
+
+		var out bytes.Buffer
+		n, err := ini.WriteTo(&out)
+		assertNoErrorMsg(t, err)
+		if n != int64(out.Len()) {
+			t.Errorf("got n = %d, want %d", n, out.Len())
+		}
+
+		roundTripped := New()
+		assertNoErrorMsg(t, roundTripped.LoadFromString(out.String()))
+		got, err := roundTripped.Get("owner", "name")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "John Doe")
+	})
+}
+
+func TestLoadSources(t *testing.T) {
+	t.Run("later sources override earlier keys and add new ones", func(t *testing.T) {
+		base := []byte("[owner]\nname = John Doe\norganization = Acme Inc.")
+		override := []byte("[owner]\norganization = Acme Corp.\n\n[database]\nserver = 192.0.2.62")
+
+		ini := New()
+		err := ini.LoadSources(base, override)
+		assertNoErrorMsg(t, err)
+
+		name, err := ini.Get("owner", "name")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, name, "John Doe")
+
+		org, err := ini.Get("owner", "organization")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, org, "Acme Corp.")
+
+		server, err := ini.Get("database", "server")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, server, "192.0.2.62")
+	})
+
+	t.Run("rejects an unsupported source type", func(t *testing.T) {
+		ini := New()
+		err := ini.LoadSources(42)
+		assertErrorMsg(t, err, ErrUnsupportedFieldType)
+	})
+
+	t.Run("reports a missing file path", func(t *testing.T) {
+		ini := New()
+		err := ini.LoadSources("ini_files/does_not_exist.ini")
+		assertErrorMsg(t, err, ErrInvalidFilePath)
+	})
+}
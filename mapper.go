@@ -0,0 +1,355 @@
+package iniparser
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultListSeparator is used to split/join slice values when a field
+// doesn't override it with a `sep:"..."` tag.
+const defaultListSeparator = ","
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// FieldError describes a failure that occurred while mapping a single
+// struct field during MapTo or ReflectFrom.
+type FieldError struct {
+	Section string
+	Field   string
+	Err     error
+}
+
+func (e *FieldError) Error() string {
+	return "field " + e.Field + " in section " + e.Section + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// MapTo marshals i's sections into the struct pointed to by v.
+// Each struct field corresponds to a section, named via an `ini:"..."` tag
+// or the field name, and each field of that nested struct corresponds to a
+// key within that section. Anonymous embedded structs flatten into the
+// section of the struct that embeds them.
+//
+// Supported field types are string, the int/uint variants, bool, the float
+// variants, time.Duration, time.Time (parsed with the `time_format` tag,
+// defaulting to time.RFC3339), and slices of any of the above (split on
+// `sep:"..."` or the parser's list separator, see SetListSeparator).
+//
+// A missing key fails with a *FieldError wrapping ErrRequiredFieldMissing
+// unless the field carries a `default:"..."` tag. A value that can't be
+// converted to the field's type fails with a *FieldError wrapping the
+// underlying conversion error.
+func (i *IniParser) MapTo(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return ErrNotAPointer
+	}
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		return ErrNotAStruct
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.mapStruct(rv, "")
+}
+
+// ReflectFrom does the reverse of MapTo: it walks v's fields and writes
+// their values into i's sections, creating sections and keys that don't
+// exist yet. The same tags and type support as MapTo apply.
+func (i *IniParser) ReflectFrom(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return ErrNotAPointer
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return ErrNotAStruct
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.sections == nil {
+		i.sections = map[SectionName]Section{}
+	}
+	return i.reflectStruct(rv, "")
+}
+
+// mapStruct walks rv's fields. Because the INI grammar this package
+// supports has no global properties, sectionName is empty only at the top
+// level, where every field must itself describe a section.
+//
+// Callers must hold i.mu; mapStruct recurses into itself for nested
+// sections and calls lookupKey, so it does not lock on its own.
+func (i *IniParser) mapStruct(rv reflect.Value, sectionName SectionName) error {
+	rt := rv.Type()
+	for idx := 0; idx < rt.NumField(); idx++ {
+		field := rt.Field(idx)
+		fieldValue := rv.Field(idx)
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		if field.Anonymous {
+			if embedded, ok := settleStruct(fieldValue); ok {
+				if err := i.mapStruct(embedded, sectionName); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		name := fieldTagName(field)
+
+		if isSectionField(fieldValue) {
+			nested, _ := settleStruct(fieldValue)
+			if err := i.mapStruct(nested, SectionName(name)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if sectionName == "" {
+			return &FieldError{Field: field.Name, Err: ErrGlobalProperity}
+		}
+
+		raw, ok := i.lookupKey(sectionName, Key(name))
+		if !ok {
+			def, hasDefault := field.Tag.Lookup("default")
+			if !hasDefault {
+				return &FieldError{Section: string(sectionName), Field: field.Name, Err: ErrRequiredFieldMissing}
+			}
+			raw = def
+		}
+
+		if err := i.setFieldValue(fieldValue, raw, field); err != nil {
+			return &FieldError{Section: string(sectionName), Field: field.Name, Err: err}
+		}
+	}
+	return nil
+}
+
+// reflectStruct is the ReflectFrom counterpart of mapStruct; callers must
+// hold i.mu for the same reason.
+func (i *IniParser) reflectStruct(rv reflect.Value, sectionName SectionName) error {
+	rt := rv.Type()
+	for idx := 0; idx < rt.NumField(); idx++ {
+		field := rt.Field(idx)
+		fieldValue := rv.Field(idx)
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		if field.Anonymous {
+			if embedded, ok := settleStruct(fieldValue); ok {
+				if err := i.reflectStruct(embedded, sectionName); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		name := fieldTagName(field)
+
+		if isSectionField(fieldValue) {
+			nested, _ := settleStruct(fieldValue)
+			if _, ok := i.sections[SectionName(name)]; !ok {
+				i.sections[SectionName(name)] = Section{}
+				i.sectionOrder = append(i.sectionOrder, SectionName(name))
+			}
+			if err := i.reflectStruct(nested, SectionName(name)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if sectionName == "" {
+			return &FieldError{Field: field.Name, Err: ErrGlobalProperity}
+		}
+
+		raw, err := i.formatFieldValue(fieldValue, field)
+		if err != nil {
+			return &FieldError{Section: string(sectionName), Field: field.Name, Err: err}
+		}
+		section := i.sections[sectionName]
+		section.Set(Key(name), raw)
+		i.sections[sectionName] = section
+	}
+	return nil
+}
+
+// lookupKey is a raw map lookup used by mapStruct; callers must hold i.mu.
+func (i *IniParser) lookupKey(sectionName SectionName, key Key) (string, bool) {
+	if i.sections == nil {
+		return "", false
+	}
+	section, ok := i.sections[sectionName]
+	if !ok {
+		return "", false
+	}
+	return section.Get(key)
+}
+
+// settleStruct dereferences v if it's a pointer (allocating it when nil)
+// and reports whether the result is a struct eligible to be treated as a
+// section or an embedded field, i.e. anything but time.Time.
+func settleStruct(v reflect.Value) (reflect.Value, bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.Type().Elem() != timeType && v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || v.Type() == timeType {
+		return v, false
+	}
+	return v, true
+}
+
+func isSectionField(v reflect.Value) bool {
+	t := v.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != timeType
+}
+
+func fieldTagName(field reflect.StructField) string {
+	if name, ok := field.Tag.Lookup("ini"); ok && name != "" {
+		return name
+	}
+	return field.Name
+}
+
+func (i *IniParser) listSeparatorFor(field reflect.StructField) string {
+	if sep, ok := field.Tag.Lookup("sep"); ok && sep != "" {
+		return sep
+	}
+	if i.listSeparator != "" {
+		return i.listSeparator
+	}
+	return defaultListSeparator
+}
+
+func (i *IniParser) setFieldValue(fv reflect.Value, raw string, field reflect.StructField) error {
+	if fv.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+	if fv.Type() == timeType {
+		layout := field.Tag.Get("time_format")
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		t, err := time.Parse(layout, raw)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Slice:
+		sep := i.listSeparatorFor(field)
+		parts := strings.Split(raw, sep)
+		slice := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+		for idx, part := range parts {
+			if err := i.setFieldValue(slice.Index(idx), strings.TrimSpace(part), field); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+	default:
+		return ErrUnsupportedFieldType
+	}
+	return nil
+}
+
+func (i *IniParser) formatFieldValue(fv reflect.Value, field reflect.StructField) (string, error) {
+	if fv.Type() == durationType {
+		return time.Duration(fv.Int()).String(), nil
+	}
+	if fv.Type() == timeType {
+		layout := field.Tag.Get("time_format")
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		return fv.Interface().(time.Time).Format(layout), nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		return fv.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(fv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(fv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(fv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(fv.Float(), 'f', -1, fv.Type().Bits()), nil
+	case reflect.Slice:
+		sep := i.listSeparatorFor(field)
+		parts := make([]string, fv.Len())
+		for idx := 0; idx < fv.Len(); idx++ {
+			part, err := i.formatFieldValue(fv.Index(idx), field)
+			if err != nil {
+				return "", err
+			}
+			parts[idx] = part
+		}
+		return strings.Join(parts, sep), nil
+	default:
+		return "", ErrUnsupportedFieldType
+	}
+}
+
+// SetListSeparator overrides the separator used to split and join slice
+// values that don't carry their own `sep:"..."` tag. The default is ",".
+func (i *IniParser) SetListSeparator(sep string) {
+	i.listSeparator = sep
+}
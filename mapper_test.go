@@ -0,0 +1,198 @@
+package iniparser
+
+import (
+	"testing"
+	"time"
+)
+
+type ownerSection struct {
+	Name         string `ini:"name"`
+	Organization string `ini:"organization"`
+}
+
+type databaseSection struct {
+	Server string `ini:"server"`
+	Port   int    `ini:"port"`
+	File   string `ini:"file"`
+}
+
+type testConfig struct {
+	Owner    ownerSection    `ini:"owner"`
+	Database databaseSection `ini:"database"`
+}
+
+func TestMapTo(t *testing.T) {
+	t.Run("maps sections into struct", func(t *testing.T) {
+		ini := New()
+		ini.LoadFromString(iniContent)
+
+		var cfg testConfig
+		err := ini.MapTo(&cfg)
+
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, cfg.Owner.Name, "John Doe")
+		assertEqualStrings(t, cfg.Database.Server, "192.0.2.62")
+		if cfg.Database.Port != 143 {
+			t.Errorf("got port %d want 143", cfg.Database.Port)
+		}
+	})
+
+	t.Run("not a pointer", func(t *testing.T) {
+		ini := New()
+		ini.LoadFromString(iniContent)
+
+		err := ini.MapTo(testConfig{})
+		assertErrorMsg(t, err, ErrNotAPointer)
+	})
+
+	t.Run("missing required field", func(t *testing.T) {
+		type withRequired struct {
+			Owner struct {
+				Address string `ini:"address"`
+			} `ini:"owner"`
+		}
+		ini := New()
+		ini.LoadFromString(iniContent)
+
+		var cfg withRequired
+		err := ini.MapTo(&cfg)
+		if err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+		fieldErr, ok := err.(*FieldError)
+		if !ok {
+			t.Fatalf("got error of type %T, want *FieldError", err)
+		}
+		assertErrorMsg(t, fieldErr.Unwrap(), ErrRequiredFieldMissing)
+	})
+
+	t.Run("default tag fills missing key", func(t *testing.T) {
+		type withDefault struct {
+			Owner struct {
+				Address string `ini:"address" default:"unknown"`
+			} `ini:"owner"`
+		}
+		ini := New()
+		ini.LoadFromString(iniContent)
+
+		var cfg withDefault
+		err := ini.MapTo(&cfg)
+
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, cfg.Owner.Address, "unknown")
+	})
+
+	t.Run("anonymous struct flattens into parent section", func(t *testing.T) {
+		type OwnerBase struct {
+			Name string `ini:"name"`
+		}
+		type withEmbedded struct {
+			Owner struct {
+				OwnerBase
+				Organization string `ini:"organization"`
+			} `ini:"owner"`
+		}
+		ini := New()
+		ini.LoadFromString(iniContent)
+
+		var cfg withEmbedded
+		err := ini.MapTo(&cfg)
+
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, cfg.Owner.Name, "John Doe")
+	})
+
+	t.Run("slice field splits on list separator", func(t *testing.T) {
+		type withSlice struct {
+			Owner struct {
+				Tags []string `ini:"tags"`
+			} `ini:"owner"`
+		}
+		ini := New()
+		ini.LoadFromString("[owner]\ntags = a,b,c")
+
+		var cfg withSlice
+		err := ini.MapTo(&cfg)
+
+		assertNoErrorMsg(t, err)
+		if len(cfg.Owner.Tags) != 3 || cfg.Owner.Tags[1] != "b" {
+			t.Errorf("got %v want [a b c]", cfg.Owner.Tags)
+		}
+	})
+
+	t.Run("time.Duration and time.Time fields", func(t *testing.T) {
+		type withTimes struct {
+			Owner struct {
+				Timeout  time.Duration `ini:"timeout"`
+				Birthday time.Time     `ini:"birthday" time_format:"2006-01-02"`
+			} `ini:"owner"`
+		}
+		ini := New()
+		ini.LoadFromString("[owner]\ntimeout = 5s\nbirthday = 2001-04-01")
+
+		var cfg withTimes
+		err := ini.MapTo(&cfg)
+
+		assertNoErrorMsg(t, err)
+		if cfg.Owner.Timeout != 5*time.Second {
+			t.Errorf("got timeout %v want 5s", cfg.Owner.Timeout)
+		}
+		want := time.Date(2001, time.April, 1, 0, 0, 0, 0, time.UTC)
+		if !cfg.Owner.Birthday.Equal(want) {
+			t.Errorf("got birthday %v want %v", cfg.Owner.Birthday, want)
+		}
+	})
+}
+
+func TestReflectFrom(t *testing.T) {
+	t.Run("writes struct fields back into sections", func(t *testing.T) {
+		ini := New()
+		cfg := testConfig{
+			Owner:    ownerSection{Name: "John Doe", Organization: "Acme Inc."},
+			Database: databaseSection{Server: "192.0.2.62", Port: 143, File: "payroll.dat"},
+		}
+
+		err := ini.ReflectFrom(&cfg)
+
+		assertNoErrorMsg(t, err)
+		got, err := ini.Get("owner", "name")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "John Doe")
+
+		got, err = ini.Get("database", "port")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "143")
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		ini := New()
+		err := ini.ReflectFrom("not a struct")
+		assertErrorMsg(t, err, ErrNotAStruct)
+	})
+
+	t.Run("unexported fields are skipped, not serialized or panicked on", func(t *testing.T) {
+		type withUnexported struct {
+			Owner struct {
+				Name     string `ini:"name"`
+				secret   string
+				modified time.Time
+			} `ini:"owner"`
+		}
+		ini := New()
+		cfg := withUnexported{}
+		cfg.Owner.Name = "John Doe"
+		cfg.Owner.secret = "hunter2"
+		cfg.Owner.modified = time.Now()
+
+		err := ini.ReflectFrom(&cfg)
+
+		assertNoErrorMsg(t, err)
+		got, err := ini.Get("owner", "name")
+		assertNoErrorMsg(t, err)
+		assertEqualStrings(t, got, "John Doe")
+
+		if ini.HasKey("owner", "secret") || ini.HasKey("owner", "modified") {
+			t.Errorf("unexported fields should not be written to the ini data: %v", ini.GetSections()["owner"])
+		}
+	})
+}
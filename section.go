@@ -0,0 +1,167 @@
+package iniparser
+
+import (
+	"sort"
+	"strings"
+)
+
+type entryKind int
+
+const (
+	keyValueEntry entryKind = iota
+	commentEntry
+	blankEntry
+)
+
+// entry is one line of a Section's body, kept in file order so a Section
+// can be written back out the way it was read in.
+type entry struct {
+	kind  entryKind
+	key   Key
+	value string
+	// text holds the raw line for a standalone comment entry.
+	text string
+	// leadingComments are comment lines that appeared directly above this
+	// key/value line, not separated from it by a blank line.
+	leadingComments []string
+	// inlineComment is the trailing comment on a key/value line, if any,
+	// with its leading ";" or "#" stripped. Only populated when inline
+	// comments were enabled on the parser that produced this entry.
+	inlineComment string
+}
+
+// Section is an ordered view of one INI section's body: its key/value
+// pairs plus any comments and blank lines interleaved between them, so that
+// LoadFromString followed by String() round-trips a human-authored file
+// without scrambling it.
+type Section struct {
+	entries []entry
+	index   map[Key]int
+}
+
+// Pair is a single key/value entry, as returned by Section.Pairs.
+type Pair struct {
+	Key   Key
+	Value string
+}
+
+// Get returns the value stored under key and whether it was present.
+func (s Section) Get(key Key) (string, bool) {
+	idx, ok := s.index[key]
+	if !ok {
+		return "", false
+	}
+	return s.entries[idx].value, true
+}
+
+// HasKey reports whether key exists in the section.
+func (s Section) HasKey(key Key) bool {
+	_, ok := s.index[key]
+	return ok
+}
+
+// Set updates key's value in place if it already exists, preserving its
+// position in the section, or appends a new key/value entry at the end.
+func (s *Section) Set(key Key, value string) {
+	if idx, ok := s.index[key]; ok {
+		s.entries[idx].value = value
+		return
+	}
+	s.appendKeyValue(key, value, nil, "")
+}
+
+// Keys returns the section's key names in file order.
+func (s Section) Keys() []Key {
+	keys := make([]Key, 0, len(s.index))
+	for _, e := range s.entries {
+		if e.kind == keyValueEntry {
+			keys = append(keys, e.key)
+		}
+	}
+	return keys
+}
+
+// Pairs returns the section's key/value entries in file order, excluding
+// comments and blank lines.
+func (s Section) Pairs() []Pair {
+	pairs := make([]Pair, 0, len(s.index))
+	for _, e := range s.entries {
+		if e.kind == keyValueEntry {
+			pairs = append(pairs, Pair{Key: e.key, Value: e.value})
+		}
+	}
+	return pairs
+}
+
+// Len returns the number of key/value pairs in the section.
+func (s Section) Len() int {
+	return len(s.index)
+}
+
+// Delete removes key from the section, if present, preserving the order
+// and comments of the remaining entries. It reports whether key was
+// present.
+func (s *Section) Delete(key Key) bool {
+	idx, ok := s.index[key]
+	if !ok {
+		return false
+	}
+	s.entries = append(s.entries[:idx], s.entries[idx+1:]...)
+	delete(s.index, key)
+	for k, i := range s.index {
+		if i > idx {
+			s.index[k] = i - 1
+		}
+	}
+	return true
+}
+
+// clone returns a deep copy of s, so that mutating the copy's entries
+// cannot race with mutations to s.
+func (s Section) clone() Section {
+	entries := make([]entry, len(s.entries))
+	for idx, e := range s.entries {
+		e.leadingComments = append([]string(nil), e.leadingComments...)
+		entries[idx] = e
+	}
+	index := make(map[Key]int, len(s.index))
+	for k, v := range s.index {
+		index[k] = v
+	}
+	return Section{entries: entries, index: index}
+}
+
+// String renders the section's key/value pairs the way fmt renders a plain
+// map, keeping fmt.Println(ini.sections)-style debugging output stable.
+func (s Section) String() string {
+	keys := s.Keys()
+	sort.Slice(keys, func(a, b int) bool { return keys[a] < keys[b] })
+	parts := make([]string, len(keys))
+	for idx, k := range keys {
+		v, _ := s.Get(k)
+		parts[idx] = string(k) + ":" + v
+	}
+	return "map[" + strings.Join(parts, " ") + "]"
+}
+
+func (s *Section) appendKeyValue(key Key, value string, leadingComments []string, inlineComment string) {
+	if s.index == nil {
+		s.index = map[Key]int{}
+	}
+	s.index[key] = len(s.entries)
+	s.entries = append(s.entries, entry{
+		kind:            keyValueEntry,
+		key:             key,
+		value:           value,
+		leadingComments: leadingComments,
+		inlineComment:   inlineComment,
+	})
+}
+
+func (s *Section) appendComment(text string) {
+	s.entries = append(s.entries, entry{kind: commentEntry, text: text})
+}
+
+func (s *Section) appendBlank() {
+	s.entries = append(s.entries, entry{kind: blankEntry})
+}
@@ -0,0 +1,50 @@
+package iniparser
+
+import "testing"
+
+func TestSectionOrderPreservedOnRoundTrip(t *testing.T) {
+	content := `; top of file
+[owner]
+; who owns this
+name = John Doe
+organization = Acme Inc.
+
+[database]
+server = 192.0.2.62
+port = 143`
+
+	ini := New()
+	err := ini.LoadFromString(content)
+	assertNoErrorMsg(t, err)
+
+	out, err := ini.String()
+	assertNoErrorMsg(t, err)
+	assertEqualStrings(t, out, content+"\n")
+}
+
+func TestSetUpdatesInPlaceWithoutReordering(t *testing.T) {
+	ini := New()
+	err := ini.LoadFromString("[owner]\nname = John Doe\norganization = Acme Inc.")
+	assertNoErrorMsg(t, err)
+
+	err = ini.Set("owner", "name", "Ahmed Salah")
+	assertNoErrorMsg(t, err)
+
+	got := ini.sections["owner"].Keys()
+	want := []Key{"name", "organization"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("got key order %v want %v", got, want)
+	}
+}
+
+func TestGetSectionNamesFileOrder(t *testing.T) {
+	ini := New()
+	err := ini.LoadFromString("[z_section]\nkey = 1\n\n[a_section]\nkey = 2")
+	assertNoErrorMsg(t, err)
+
+	got := ini.GetSectionNames()
+	want := []string{"z_section", "a_section"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("got %v want %v", got, want)
+	}
+}